@@ -4,16 +4,39 @@ import (
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"io"
+	"io/fs"
+	"mime"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/go-kratos/kratos/v2/encoding"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport/http/binding"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 )
 
+// acceptCodecs maps the Accept media types Render understands to codec names.
+// Render honors the order of the client's Accept header; the order of this
+// list is the deterministic fallback order used when no Accept entry names a
+// registered codec.
+var acceptCodecs = []struct {
+	mimeType string
+	codec    string
+}{
+	{"application/json", "json"},
+	{"application/xml", "xml"},
+	{"application/x-protobuf", "proto"},
+	{"application/msgpack", "msgpack"},
+	{"application/x-www-form-urlencoded", "form"},
+}
+
+var errCodecNotRegistered = errors.New("http: codec not registered")
+
 var _ Context = (*wrapper)(nil)
 
 // HandlerFunc defines a function to serve HTTP requests.
@@ -33,13 +56,24 @@ type Context interface {
 	BindVars(interface{}) error
 	BindQuery(interface{}) error
 	BindForm(interface{}) error
+	BindJSON(interface{}) error
+	BindXML(interface{}) error
 	Returns(interface{}, error) error
 	Result(int, interface{}) error
 	JSON(int, interface{}) error
 	XML(int, interface{}) error
+	MsgPack(int, interface{}) error
+	Render(int, interface{}) error
 	String(int, string) error
 	Blob(int, string, []byte) error
 	Stream(int, string, io.Reader) error
+	SSE(...SSEOption) (SSEWriter, error)
+	SSEvent(string, interface{}) error
+	Upgrade(...UpgradeOption) (*websocket.Conn, error)
+	File(string) error
+	FileAttachment(string, string) error
+	Inline(string, string) error
+	FileFS(fs.FS, string) error
 	Reset(http.ResponseWriter, *http.Request)
 }
 
@@ -47,18 +81,26 @@ type wrapper struct {
 	route *Route
 	req   *http.Request
 	res   http.ResponseWriter
+	vars  url.Values
+	sse   *sseWriter
 }
 
 func (c *wrapper) Header() http.Header {
 	return c.req.Header
 }
 
+// Vars lazily computes and caches the path parameters for the lifetime of
+// the request, so repeated calls don't re-invoke mux.Vars and re-allocate.
 func (c *wrapper) Vars() url.Values {
+	if c.vars != nil {
+		return c.vars
+	}
 	raws := mux.Vars(c.req)
 	vars := make(url.Values, len(raws))
 	for k, v := range raws {
 		vars[k] = []string{v}
 	}
+	c.vars = vars
 	return vars
 }
 func (c *wrapper) Form() url.Values {
@@ -75,10 +117,57 @@ func (c *wrapper) Response() http.ResponseWriter { return c.res }
 func (c *wrapper) Middleware(h middleware.Handler) middleware.Handler {
 	return middleware.Chain(c.route.srv.ms...)(h)
 }
-func (c *wrapper) Bind(v interface{}) error      { return c.route.srv.dec(c.req, v) }
-func (c *wrapper) BindVars(v interface{}) error  { return binding.BindQuery(c.Vars(), v) }
-func (c *wrapper) BindQuery(v interface{}) error { return binding.BindQuery(c.Query(), v) }
-func (c *wrapper) BindForm(v interface{}) error  { return binding.BindForm(c.req, v) }
+func (c *wrapper) Bind(v interface{}) error {
+	if err := c.route.srv.dec(c.req, v); err != nil {
+		return err
+	}
+	return c.validate(v)
+}
+func (c *wrapper) BindVars(v interface{}) error {
+	if err := binding.BindQuery(c.Vars(), v); err != nil {
+		return err
+	}
+	return c.validate(v)
+}
+func (c *wrapper) BindQuery(v interface{}) error {
+	if err := binding.BindQuery(c.Query(), v); err != nil {
+		return err
+	}
+	return c.validate(v)
+}
+func (c *wrapper) BindForm(v interface{}) error {
+	if err := binding.BindForm(c.req, v); err != nil {
+		return err
+	}
+	return c.validate(v)
+}
+
+// BindJSON decodes the request body as JSON regardless of Content-Type,
+// matching the Gin idiom of forcing a codec instead of negotiating one.
+func (c *wrapper) BindJSON(v interface{}) error {
+	if err := json.NewDecoder(c.req.Body).Decode(v); err != nil {
+		return err
+	}
+	return c.validate(v)
+}
+
+// BindXML decodes the request body as XML regardless of Content-Type.
+func (c *wrapper) BindXML(v interface{}) error {
+	if err := xml.NewDecoder(c.req.Body).Decode(v); err != nil {
+		return err
+	}
+	return c.validate(v)
+}
+
+// validate runs the server's configured Validator (or the default
+// validator.v10-backed one) against v after a successful Bind.
+func (c *wrapper) validate(v interface{}) error {
+	validator := c.route.srv.validator
+	if validator == nil {
+		validator = defaultValidator()
+	}
+	return validator.Validate(v)
+}
 func (c *wrapper) Returns(v interface{}, err error) error {
 	if err != nil {
 		return err
@@ -105,6 +194,62 @@ func (c *wrapper) XML(code int, v interface{}) error {
 	c.res.Header().Set("Content-Type", "application/xml")
 	return xml.NewEncoder(c.res).Encode(v)
 }
+func (c *wrapper) MsgPack(code int, v interface{}) error {
+	codec := encoding.GetCodec("msgpack")
+	if codec == nil {
+		return errCodecNotRegistered
+	}
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.res.Header().Set("Content-Type", "application/msgpack")
+	c.res.WriteHeader(code)
+	_, err = c.res.Write(data)
+	return err
+}
+
+// Render negotiates the response encoding from the request's Accept header
+// and dispatches to the matching registered encoding.Codec, falling back to
+// the first registered codec in acceptCodecs order (JSON, when registered)
+// when no codec matches (or no Accept is sent).
+func (c *wrapper) Render(code int, v interface{}) error {
+	name, contentType := "", ""
+accept:
+	for _, accept := range strings.Split(c.req.Header.Get("Accept"), ",") {
+		mimeType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		for _, ac := range acceptCodecs {
+			if mimeType == ac.mimeType && encoding.GetCodec(ac.codec) != nil {
+				name, contentType = ac.codec, ac.mimeType
+				break accept
+			}
+		}
+	}
+	if name == "" {
+		for _, ac := range acceptCodecs {
+			if encoding.GetCodec(ac.codec) != nil {
+				name, contentType = ac.codec, ac.mimeType
+				break
+			}
+		}
+	}
+	codec := encoding.GetCodec(name)
+	if codec == nil {
+		return errCodecNotRegistered
+	}
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.res.Header().Set("Content-Type", contentType)
+	c.res.WriteHeader(code)
+	_, err = c.res.Write(data)
+	return err
+}
+
 func (c *wrapper) String(code int, text string) error {
 	c.res.WriteHeader(code)
 	c.res.Header().Set("Content-Type", "text/plain")
@@ -123,9 +268,19 @@ func (c *wrapper) Stream(code int, contentType string, rd io.Reader) error {
 	_, err := io.Copy(c.res, rd)
 	return err
 }
+
+// Reset rebinds c to a new request/response pair, first closing and joining
+// any SSE writer left over from a prior request handled by this (pooled) c
+// so its heartbeat goroutine has stopped writing to the previous response
+// before c is reused.
 func (c *wrapper) Reset(res http.ResponseWriter, req *http.Request) {
+	if c.sse != nil {
+		c.sse.Close()
+		c.sse = nil
+	}
 	c.res = res
 	c.req = req
+	c.vars = nil
 }
 func (c *wrapper) Deadline() (time.Time, bool) {
 	if c.req == nil {
@@ -150,4 +305,4 @@ func (c *wrapper) Value(key interface{}) interface{} {
 		return nil
 	}
 	return c.req.Context().Value(key)
-}
\ No newline at end of file
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/encoding"
+)
+
+type stubCodec struct{ name string }
+
+func (s stubCodec) Marshal(v interface{}) ([]byte, error)      { return []byte(s.name), nil }
+func (s stubCodec) Unmarshal(data []byte, v interface{}) error { return nil }
+func (s stubCodec) Name() string                               { return s.name }
+
+func init() {
+	encoding.RegisterCodec(stubCodec{name: "json"})
+	encoding.RegisterCodec(stubCodec{name: "xml"})
+}
+
+func newTestContext(res *httptest.ResponseRecorder, req *http.Request) *wrapper {
+	ctx := &wrapper{route: &Route{srv: &Server{}}}
+	ctx.Reset(res, req)
+	return ctx
+}
+
+func TestRenderPrefersFirstListedAcceptType(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept", "application/xml, application/json")
+	res := httptest.NewRecorder()
+
+	if err := newTestContext(res, req).Render(http.StatusOK, "v"); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if ct := res.Result().Header.Get("Content-Type"); ct != "application/xml" {
+		t.Fatalf("Content-Type = %q, want application/xml", ct)
+	}
+}
+
+func TestRenderFallsBackToJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept", "application/x-protobuf")
+	res := httptest.NewRecorder()
+
+	if err := newTestContext(res, req).Render(http.StatusOK, "v"); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if ct := res.Result().Header.Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q, want application/json", ct)
+	}
+}
+
+func TestMsgPackSetsContentTypeBeforeWriteHeader(t *testing.T) {
+	encoding.RegisterCodec(stubCodec{name: "msgpack"})
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	res := httptest.NewRecorder()
+
+	if err := newTestContext(res, req).MsgPack(http.StatusOK, "v"); err != nil {
+		t.Fatalf("MsgPack() error = %v", err)
+	}
+	if ct := res.Result().Header.Get("Content-Type"); ct != "application/msgpack" {
+		t.Fatalf("Content-Type = %q, want application/msgpack (header set after WriteHeader is dropped)", ct)
+	}
+}
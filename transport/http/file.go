@@ -0,0 +1,136 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+// ReasonFileNotFound is the Reason set on the *errors.Error returned when
+// File, FileAttachment, Inline or FileFS is asked to serve a path that does
+// not exist.
+const ReasonFileNotFound = "FILE_NOT_FOUND"
+
+// File serves the file at path, honoring If-Modified-Since, If-None-Match
+// and Range headers via http.ServeContent.
+func (c *wrapper) File(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fileOpenError(err, path)
+	}
+	defer f.Close()
+	return serveContent(c.res, c.req, f)
+}
+
+// FileAttachment serves the file at path as a download, setting
+// Content-Disposition: attachment with an RFC 5987 UTF-8 encoded filename.
+func (c *wrapper) FileAttachment(path, name string) error {
+	return c.sendFile(path, "attachment", name)
+}
+
+// Inline serves the file at path for display within the browser, setting
+// Content-Disposition: inline with an RFC 5987 UTF-8 encoded filename.
+func (c *wrapper) Inline(path, name string) error {
+	return c.sendFile(path, "inline", name)
+}
+
+func (c *wrapper) sendFile(path, disposition, name string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fileOpenError(err, path)
+	}
+	defer f.Close()
+	c.res.Header().Set("Content-Disposition", contentDisposition(disposition, name))
+	return serveContent(c.res, c.req, f)
+}
+
+// FileFS serves path from fsys, e.g. an embed.FS, the same way File does.
+func (c *wrapper) FileFS(fsys fs.FS, path string) error {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return fileOpenError(err, path)
+	}
+	defer f.Close()
+	return serveContent(c.res, c.req, f)
+}
+
+// fileOpenError maps a missing file to a 404 *errors.Error so it reaches the
+// client via Route.ServeHTTP's errors.FromError handling instead of a bare
+// 500, while any other open failure (permissions, I/O) is returned as-is.
+func fileOpenError(err error, path string) error {
+	if os.IsNotExist(err) {
+		return errors.NotFound(ReasonFileNotFound, fmt.Sprintf("file %s not found", path))
+	}
+	return err
+}
+
+type readSeekCloser interface {
+	fs.File
+	io.Seeker
+}
+
+func serveContent(res http.ResponseWriter, req *http.Request, f fs.File) error {
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if stat.IsDir() {
+		return fmt.Errorf("http: %s is a directory", stat.Name())
+	}
+	rs, ok := f.(readSeekCloser)
+	if !ok {
+		return fmt.Errorf("http: file %s does not support seeking", stat.Name())
+	}
+	res.Header().Set("Etag", etag(stat.ModTime().UnixNano(), stat.Size()))
+	http.ServeContent(res, req, stat.Name(), stat.ModTime(), rs)
+	return nil
+}
+
+// etag builds a weak entity tag from a file's modification time and size,
+// cheap enough to compute per request without reading the file's content,
+// and good enough to make If-None-Match work against an unmodified file.
+func etag(modTimeUnixNano int64, size int64) string {
+	return `W/"` + strconv.FormatInt(modTimeUnixNano, 36) + "-" + strconv.FormatInt(size, 36) + `"`
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// rfc5987AttrChars is the RFC 5987 attr-char set: everything else in a
+// filename* value must be percent-encoded.
+const rfc5987AttrChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-._~"
+
+// rfc5987Escape percent-encodes s for use as an RFC 5987 ext-value, which is
+// stricter than url.PathEscape (e.g. it still escapes ":", "@", "=", "&",
+// "+", and "$", none of which url.PathEscape touches).
+func rfc5987Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if strings.IndexByte(rfc5987AttrChars, c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// contentDisposition builds a Content-Disposition header value with an
+// RFC 5987 UTF-8 encoded filename* alongside a quoted-ASCII fallback for
+// older clients.
+func contentDisposition(disposition, name string) string {
+	fallback := []byte(name)
+	for i, c := range fallback {
+		if c >= 0x80 {
+			fallback[i] = '_'
+		}
+	}
+	return fmt.Sprintf(`%s; filename="%s"; filename*=UTF-8''%s`,
+		disposition, quoteEscaper.Replace(string(fallback)), rfc5987Escape(name))
+}
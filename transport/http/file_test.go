@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+func TestFileMissingReturnsNotFound(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	res := httptest.NewRecorder()
+	ctx := &wrapper{route: &Route{srv: &Server{}}}
+	ctx.Reset(res, req)
+
+	err := ctx.File(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err == nil {
+		t.Fatal("File() error = nil, want a not-found error")
+	}
+	se := errors.FromError(err)
+	if se.Code != http.StatusNotFound {
+		t.Fatalf("Code = %d, want %d", se.Code, http.StatusNotFound)
+	}
+}
+
+func TestFileSetsEtag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	res := httptest.NewRecorder()
+	ctx := &wrapper{route: &Route{srv: &Server{}}}
+	ctx.Reset(res, req)
+
+	if err := ctx.File(path); err != nil {
+		t.Fatalf("File() error = %v", err)
+	}
+	if res.Result().Header.Get("Etag") == "" {
+		t.Fatal("Etag header not set")
+	}
+}
+
+func TestRFC5987EscapeEscapesReservedChars(t *testing.T) {
+	got := rfc5987Escape(`a:b@c=d,e&f+g$h.txt`)
+	want := "a%3Ab%40c%3Dd%2Ce%26f%2Bg%24h.txt"
+	if got != want {
+		t.Fatalf("rfc5987Escape() = %q, want %q", got, want)
+	}
+}
+
+func TestContentDispositionUsesRFC5987Escape(t *testing.T) {
+	got := contentDisposition("attachment", "a:b.txt")
+	want := `attachment; filename="a:b.txt"; filename*=UTF-8''a%3Ab.txt`
+	if got != want {
+		t.Fatalf("contentDisposition() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+)
+
+// wrapperPool recycles *wrapper instances across requests, mirroring the
+// context-pooling approach used by Gin to cut per-request allocations.
+// Route.ServeHTTP acquires a wrapper via acquireWrapper and returns it with
+// releaseWrapper once the handler chain has run.
+var wrapperPool = sync.Pool{
+	New: func() interface{} { return &wrapper{} },
+}
+
+func acquireWrapper(route *Route, res http.ResponseWriter, req *http.Request) *wrapper {
+	w := wrapperPool.Get().(*wrapper)
+	w.route = route
+	w.Reset(res, req)
+	return w
+}
+
+func releaseWrapper(w *wrapper) {
+	w.route = nil
+	w.Reset(nil, nil)
+	wrapperPool.Put(w)
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/gorilla/mux"
+)
+
+func noopMiddleware(next middleware.Handler) middleware.Handler {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return next(ctx, req)
+	}
+}
+
+func benchRoute() *Route {
+	return &Route{
+		srv: &Server{
+			ms: []middleware.Middleware{noopMiddleware, noopMiddleware, noopMiddleware},
+		},
+	}
+}
+
+func benchRequest() (*httptest.ResponseRecorder, *http.Request) {
+	req := httptest.NewRequest(http.MethodGet, "/a/1/b/2/c/3/d/4/e/5/f/6/g/7/h/8", http.NoBody)
+	req = mux.SetURLVars(req, map[string]string{
+		"a": "1", "b": "2", "c": "3", "d": "4",
+		"e": "5", "f": "6", "g": "7", "h": "8",
+	})
+	return httptest.NewRecorder(), req
+}
+
+func exerciseWrapper(ctx *wrapper, cacheVars bool) {
+	ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	})
+	for i := 0; i < 4; i++ {
+		if !cacheVars {
+			ctx.vars = nil
+		}
+		_ = ctx.Vars()
+	}
+}
+
+// BenchmarkWrapperNoPool measures the pre-pooling cost: a fresh *wrapper
+// allocated per request, with Vars() re-invoking mux.Vars on every call.
+func BenchmarkWrapperNoPool(b *testing.B) {
+	route := benchRoute()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		res, req := benchRequest()
+		ctx := &wrapper{route: route}
+		ctx.Reset(res, req)
+		exerciseWrapper(ctx, false)
+	}
+}
+
+// BenchmarkWrapperPool measures the pooled cost: acquireWrapper/releaseWrapper
+// recycling a *wrapper across requests, with Vars() cached per request.
+func BenchmarkWrapperPool(b *testing.B) {
+	route := benchRoute()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		res, req := benchRequest()
+		ctx := acquireWrapper(route, res, req)
+		exerciseWrapper(ctx, true)
+		releaseWrapper(ctx)
+	}
+}
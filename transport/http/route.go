@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+// Route is a single registered HTTP route. It implements http.Handler so it
+// can be wired directly into the server's router.
+type Route struct {
+	srv    *Server
+	method string
+	path   string
+	h      HandlerFunc
+}
+
+// Handle registers h to serve method requests for path on the same Server
+// as r, returning the new Route.
+func (r *Route) Handle(method, path string, h HandlerFunc) *Route {
+	route := &Route{srv: r.srv, method: method, path: path, h: h}
+	r.srv.router.Handle(path, route).Methods(method)
+	return route
+}
+
+// ServeHTTP implements http.Handler, dispatching to the route's handler
+// through a pooled Context for this request. A handler error is converted
+// via errors.FromError so a *errors.Error built by e.g. errors.BadRequest or
+// errors.NotFound reaches the client with its own status code and message
+// instead of a bare 500.
+func (r *Route) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	ctx := acquireWrapper(r, res, req)
+	defer releaseWrapper(ctx)
+	if err := r.h(ctx); err != nil {
+		se := errors.FromError(err)
+		http.Error(res, se.Message, int(se.Code))
+	}
+}
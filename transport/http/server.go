@@ -0,0 +1,29 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/gorilla/mux"
+)
+
+// DecodeRequestFunc decodes an HTTP request body into v.
+type DecodeRequestFunc func(*http.Request, interface{}) error
+
+// EncodeResponseFunc encodes v as the HTTP response for req.
+type EncodeResponseFunc func(http.ResponseWriter, *http.Request, interface{}) error
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// Server is an HTTP server built on gorilla/mux.
+type Server struct {
+	router *mux.Router
+	ms     []middleware.Middleware
+	dec    DecodeRequestFunc
+	enc    EncodeResponseFunc
+	// validator is run by wrapper.validate after a successful Bind. It is
+	// nil by default, in which case the package-wide validator.v10-backed
+	// instance from defaultValidator is used instead.
+	validator Validator
+}
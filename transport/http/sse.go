@@ -0,0 +1,190 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+var errSSEUnsupported = errors.New("http: response writer does not support flushing")
+
+// defaultSSEHeartbeat is the interval at which a comment keep-alive tick is
+// written to an SSE stream when no WithSSEHeartbeat option is supplied.
+const defaultSSEHeartbeat = 15 * time.Second
+
+// SSEOption configures a SSEWriter returned by Context.SSE.
+type SSEOption func(*sseWriter)
+
+// WithSSEHeartbeat sets the interval at which a keep-alive comment is
+// written to the stream; the comment is written on every tick, whether or
+// not events were sent in between. A non-positive interval disables
+// heartbeats.
+func WithSSEHeartbeat(interval time.Duration) SSEOption {
+	return func(w *sseWriter) {
+		w.heartbeat = interval
+	}
+}
+
+// WithSSERetry sets the `retry:` field written with every event, telling the
+// client how long to wait before reconnecting after the stream drops.
+func WithSSERetry(retry time.Duration) SSEOption {
+	return func(w *sseWriter) {
+		w.retry = retry
+	}
+}
+
+// SSEWriter writes Server-Sent Events to an underlying http.ResponseWriter,
+// honoring the request context for cancellation.
+type SSEWriter interface {
+	// SSEvent writes a single event, encoding data with the server's
+	// registered encoder (JSON by default).
+	SSEvent(event string, data interface{}) error
+	// Close stops the writer's heartbeat goroutine, if any, and waits for it
+	// to exit before returning. It does not close the underlying connection.
+	Close()
+}
+
+type sseWriter struct {
+	res     http.ResponseWriter
+	flusher http.Flusher
+	ctx     *wrapper // only read synchronously from SSEvent, never from keepAlive
+
+	heartbeat time.Duration
+	retry     time.Duration
+	id        int
+
+	// done is the request's cancellation channel, captured once up front so
+	// the keepAlive goroutine never has to read it back off the pooled
+	// wrapper, which Route.ServeHTTP may recycle for another request as soon
+	// as the handler returns.
+	done <-chan struct{}
+
+	mu      sync.Mutex // guards writes to res, shared by SSEvent and keepAlive
+	stop    chan struct{}
+	stopped chan struct{} // closed once keepAlive has returned (or never started)
+}
+
+// SSE prepares the response for Server-Sent Events: it sets the streaming
+// headers, starts an optional heartbeat tick, and returns a SSEWriter used
+// to push events for the lifetime of the request.
+func (c *wrapper) SSE(opts ...SSEOption) (SSEWriter, error) {
+	flusher, ok := c.res.(http.Flusher)
+	if !ok {
+		return nil, errSSEUnsupported
+	}
+	c.res.Header().Set("Content-Type", "text/event-stream")
+	c.res.Header().Set("Cache-Control", "no-cache")
+	c.res.Header().Set("Connection", "keep-alive")
+	c.res.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	w := &sseWriter{
+		res:       c.res,
+		flusher:   flusher,
+		ctx:       c,
+		done:      c.req.Context().Done(),
+		heartbeat: defaultSSEHeartbeat,
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	for _, o := range opts {
+		o(w)
+	}
+	if w.heartbeat > 0 {
+		go w.keepAlive()
+	} else {
+		close(w.stopped)
+	}
+	c.sse = w
+	return w, nil
+}
+
+// SSEvent is a convenience for callers that only need to push a single event
+// and do not otherwise need a long-lived SSEWriter.
+func (c *wrapper) SSEvent(event string, data interface{}) error {
+	w, err := c.SSE()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	return w.SSEvent(event, data)
+}
+
+func (w *sseWriter) SSEvent(event string, data interface{}) error {
+	select {
+	case <-w.done:
+		return w.ctx.Err()
+	default:
+	}
+
+	// Encode before taking the lock: the server's encoder writes into its own
+	// recorder, not w.res, so there's nothing to hold the lock for here.
+	rec := httptest.NewRecorder()
+	if err := w.ctx.route.srv.enc(rec, w.ctx.req, data); err != nil {
+		return err
+	}
+	body := bytes.TrimRight(rec.Body.Bytes(), "\n")
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.id++
+	if _, err := fmt.Fprintf(w.res, "id: %d\n", w.id); err != nil {
+		return err
+	}
+	if event != "" {
+		if _, err := fmt.Fprintf(w.res, "event: %s\n", event); err != nil {
+			return err
+		}
+	}
+	if w.retry > 0 {
+		if _, err := fmt.Fprintf(w.res, "retry: %d\n", w.retry.Milliseconds()); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w.res, "data: %s\n\n", body); err != nil {
+		return err
+	}
+	w.flusher.Flush()
+	return nil
+}
+
+func (w *sseWriter) keepAlive() {
+	defer close(w.stopped)
+	ticker := time.NewTicker(w.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			_, err := fmt.Fprint(w.res, ": ping\n\n")
+			if err == nil {
+				w.flusher.Flush()
+			}
+			w.mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close stops the keepAlive goroutine, if any, and blocks until it has
+// actually exited so the caller can safely recycle whatever res/flusher it
+// handed to this writer (e.g. via the wrapper pool) once Close returns.
+func (w *sseWriter) Close() {
+	select {
+	case <-w.stop:
+	default:
+		close(w.stop)
+	}
+	<-w.stopped
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSSEventAndKeepAliveDoNotRace exercises concurrent writers to the
+// underlying ResponseWriter (an event producer and the heartbeat ticker) so
+// `go test -race` catches any unsynchronized access to w.res.
+func TestSSEventAndKeepAliveDoNotRace(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	res := httptest.NewRecorder()
+	ctx := &wrapper{route: &Route{srv: newSSEServer()}}
+	ctx.Reset(res, req)
+
+	w, err := ctx.SSE(WithSSEHeartbeat(time.Millisecond))
+	if err != nil {
+		t.Fatalf("SSE() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_ = w.SSEvent("tick", "payload")
+		}
+	}()
+	time.Sleep(5 * time.Millisecond)
+	wg.Wait()
+	w.Close()
+
+	if !strings.Contains(res.Body.String(), "data: payload") {
+		t.Fatalf("body = %q, want at least one event written", res.Body.String())
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newSSEServer() *Server {
+	return &Server{
+		enc: func(w http.ResponseWriter, _ *http.Request, v interface{}) error {
+			_, err := w.Write([]byte(v.(string)))
+			return err
+		},
+	}
+}
+
+// TestSSECloseJoinsKeepAliveGoroutine verifies Close blocks until the
+// heartbeat goroutine has actually exited, so callers (including
+// wrapper.Reset recycling a pooled context) never observe a write racing
+// past the point Close returned.
+func TestSSECloseJoinsKeepAliveGoroutine(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	res := httptest.NewRecorder()
+	ctx := &wrapper{route: &Route{srv: newSSEServer()}}
+	ctx.Reset(res, req)
+
+	w, err := ctx.SSE(WithSSEHeartbeat(time.Millisecond))
+	if err != nil {
+		t.Fatalf("SSE() error = %v", err)
+	}
+	sw := w.(*sseWriter)
+
+	w.Close()
+
+	select {
+	case <-sw.stopped:
+	default:
+		t.Fatal("Close() returned before keepAlive exited")
+	}
+
+	// A second Close must not block or panic now that the channel is closed.
+	w.Close()
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// ReasonValidationFailed is the Reason set on the *errors.Error returned
+// when a bound value fails validation.
+const ReasonValidationFailed = "VALIDATION_FAILED"
+
+// Validator validates the given value, typically a struct populated by one
+// of the Bind family of methods, and returns a descriptive error if any
+// field fails its rules.
+type Validator interface {
+	Validate(v interface{}) error
+}
+
+// WithValidator sets the Validator run after decoding in Bind, BindVars,
+// BindQuery, BindForm, BindJSON and BindXML. If unset, a validator.v10-backed
+// implementation is used.
+func WithValidator(v Validator) ServerOption {
+	return func(s *Server) {
+		s.validator = v
+	}
+}
+
+var (
+	defaultValidatorOnce sync.Once
+	defaultValidatorInst *structValidator
+)
+
+// defaultValidator lazily builds the package-wide go-playground/validator
+// instance used when no Validator is configured via WithValidator.
+func defaultValidator() Validator {
+	defaultValidatorOnce.Do(func() {
+		defaultValidatorInst = &structValidator{validate: validator.New()}
+	})
+	return defaultValidatorInst
+}
+
+type structValidator struct {
+	validate *validator.Validate
+}
+
+func (s *structValidator) Validate(v interface{}) error {
+	if err := s.validate.Struct(v); err != nil {
+		if fieldErrs, ok := err.(validator.ValidationErrors); ok {
+			return newValidationError(fieldErrs)
+		}
+		return err
+	}
+	return nil
+}
+
+// newValidationError builds the *errors.Error returned when a bound value
+// fails validation, carrying one message per offending field in Metadata so
+// the HTTP error response surfaces structured details instead of a bare
+// decode error.
+func newValidationError(fieldErrs validator.ValidationErrors) error {
+	fields := make(map[string]string, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		fields[fe.Field()] = fmt.Sprintf("failed on the '%s' tag", fe.Tag())
+	}
+	return errors.BadRequest(ReasonValidationFailed, "validation failed").WithMetadata(fields)
+}
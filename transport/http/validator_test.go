@@ -0,0 +1,48 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+type demoPayload struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+func TestBindJSONValidationFailureReturnsBadRequest(t *testing.T) {
+	srv := &Server{}
+	route := &Route{srv: srv}
+	route.h = func(ctx Context) error {
+		var p demoPayload
+		return ctx.BindJSON(&p)
+	}
+
+	body, _ := json.Marshal(map[string]string{"email": "not-an-email"})
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	route.ServeHTTP(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", res.Code, http.StatusBadRequest)
+	}
+}
+
+func TestNewValidationErrorCarriesFieldMetadata(t *testing.T) {
+	err := defaultValidator().Validate(&demoPayload{Email: "not-an-email"})
+	if err == nil {
+		t.Fatal("Validate() error = nil, want a validation error")
+	}
+	se := errors.FromError(err)
+	if se.Code != http.StatusBadRequest {
+		t.Fatalf("Code = %d, want %d", se.Code, http.StatusBadRequest)
+	}
+	if _, ok := se.Metadata["Email"]; !ok {
+		t.Fatalf("Metadata = %v, want an entry for the Email field", se.Metadata)
+	}
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/gorilla/websocket"
+)
+
+// WSHandlerFunc handles an upgraded WebSocket connection. It participates in
+// the same middleware chain, selector, and logging as regular handlers.
+type WSHandlerFunc func(Context, *websocket.Conn) error
+
+// UpgradeOption configures the handshake performed by Context.Upgrade.
+type UpgradeOption func(*websocket.Upgrader)
+
+// WithUpgradeSubprotocols sets the server's supported protocols in order of
+// preference; the first matching client protocol is selected.
+func WithUpgradeSubprotocols(protocols ...string) UpgradeOption {
+	return func(u *websocket.Upgrader) {
+		u.Subprotocols = protocols
+	}
+}
+
+// WithUpgradeBufferSize sets the I/O buffer sizes used for the upgraded
+// connection.
+func WithUpgradeBufferSize(read, write int) UpgradeOption {
+	return func(u *websocket.Upgrader) {
+		u.ReadBufferSize = read
+		u.WriteBufferSize = write
+	}
+}
+
+// WithUpgradeCheckOrigin overrides the default same-origin check performed
+// during the handshake.
+func WithUpgradeCheckOrigin(check func(r *http.Request) bool) UpgradeOption {
+	return func(u *websocket.Upgrader) {
+		u.CheckOrigin = check
+	}
+}
+
+// WithUpgradeHandshakeTimeout sets the handshake timeout.
+func WithUpgradeHandshakeTimeout(timeout time.Duration) UpgradeOption {
+	return func(u *websocket.Upgrader) {
+		u.HandshakeTimeout = timeout
+	}
+}
+
+// Upgrade performs the WebSocket handshake on the current request, returning
+// the upgraded connection. The request context is propagated so the
+// connection can be torn down on client disconnect or server shutdown.
+func (c *wrapper) Upgrade(opts ...UpgradeOption) (*websocket.Conn, error) {
+	upgrader := websocket.Upgrader{}
+	for _, o := range opts {
+		o(&upgrader)
+	}
+	conn, err := upgrader.Upgrade(c.res, c.req, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Capture the request's Done channel now rather than closing over c: c is
+	// a pooled *wrapper that Route.ServeHTTP recycles as soon as the
+	// (synchronous) handler returns, so by the time this goroutine runs, c
+	// may have been reset for an unrelated request or have a nil req.
+	done := c.req.Context().Done()
+	go func() {
+		<-done
+		conn.Close()
+	}()
+	return conn, nil
+}
+
+// WEBSOCKET registers a WebSocket route: h is called with the Context
+// (already carrying the negotiated middleware chain) and the upgraded
+// connection once the handshake completes.
+//
+// Neither a failed handshake nor an error from h is propagated to the
+// normal HTTP error-encoding path: websocket.Upgrader.Upgrade already writes
+// its own HTTP error response when the handshake fails, and once it
+// succeeds the connection is hijacked, so there is no HTTP response left to
+// write an error to either way. Both cases are logged instead.
+func (r *Route) WEBSOCKET(path string, h WSHandlerFunc, opts ...UpgradeOption) {
+	r.Handle(http.MethodGet, path, websocketHandler(h, opts...))
+}
+
+func websocketHandler(h WSHandlerFunc, opts ...UpgradeOption) HandlerFunc {
+	return func(ctx Context) error {
+		conn, err := ctx.(*wrapper).Upgrade(opts...)
+		if err != nil {
+			log.Context(ctx).Errorf("websocket upgrade: %v", err)
+			return nil
+		}
+		defer conn.Close()
+		if err := h(ctx, conn); err != nil {
+			log.Context(ctx).Errorf("websocket handler: %v", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWEBSOCKETFailedHandshakeDoesNotDoubleWriteResponse(t *testing.T) {
+	route := &Route{srv: &Server{}}
+	route.h = websocketHandler(func(ctx Context, conn *websocket.Conn) error {
+		t.Fatal("handler should not run when the handshake fails")
+		return nil
+	})
+
+	// A plain GET with none of the Upgrade/Connection/Sec-WebSocket-* headers
+	// makes websocket.Upgrader.Upgrade fail and write its own 400 response.
+	req := httptest.NewRequest(http.MethodGet, "/ws", http.NoBody)
+	res := httptest.NewRecorder()
+
+	route.ServeHTTP(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d (from websocket.Upgrader, not a second write by ServeHTTP)", res.Code, http.StatusBadRequest)
+	}
+}